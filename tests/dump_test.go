@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2023-present, Qihoo, Inc.  All rights reserved.
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree. An additional grant
+ * of patent rights can be found in the PATENTS file in the same directory.
+ */
+
+package pikiwidb_test
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/OpenAtomFoundation/pikiwidb/tests/util"
+)
+
+var _ = Describe("Dump and Restore", Ordered, func() {
+	var (
+		ctx    = context.TODO()
+		s      *util.Server
+		client *redis.Client
+	)
+
+	BeforeAll(func() {
+		config := util.GetConfPath(false, 0)
+
+		s = util.StartServer(config, map[string]string{"port": strconv.Itoa(7783)}, true)
+		Expect(s).NotTo(Equal(nil))
+	})
+
+	AfterAll(func() {
+		err := s.Close()
+		if err != nil {
+			log.Println("Close Server fail.", err.Error())
+			return
+		}
+	})
+
+	BeforeEach(func() {
+		client = s.NewClient()
+		Expect(client.FlushDB(ctx).Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		err := client.Close()
+		if err != nil {
+			log.Println("Close client conn fail.", err.Error())
+			return
+		}
+	})
+
+	It("should return nil DUMP for a missing key", func() {
+		Expect(client.Dump(ctx, "no_such_key").Err()).To(Equal(redis.Nil))
+	})
+
+	It("should round-trip a string through DUMP and RESTORE", func() {
+		Expect(client.Set(ctx, "dump_str", "hello world", 0).Err()).NotTo(HaveOccurred())
+
+		blob, err := client.Dump(ctx, "dump_str").Result()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(client.Restore(ctx, "dump_str_copy", 0, blob).Err()).NotTo(HaveOccurred())
+		Expect(client.Get(ctx, "dump_str_copy").Val()).To(Equal("hello world"))
+	})
+
+	It("should round-trip each data type through DUMP and RESTORE", func() {
+		Expect(client.RPush(ctx, "dump_list", "a", "b", "c").Err()).NotTo(HaveOccurred())
+		Expect(client.HSet(ctx, "dump_hash", "f1", "v1", "f2", "v2").Err()).NotTo(HaveOccurred())
+		Expect(client.SAdd(ctx, "dump_set", "x", "y", "z").Err()).NotTo(HaveOccurred())
+		Expect(client.ZAdd(ctx, "dump_zset", redis.Z{Score: 1, Member: "a"}, redis.Z{Score: 2, Member: "b"}).Err()).NotTo(HaveOccurred())
+
+		for _, key := range []string{"dump_list", "dump_hash", "dump_set", "dump_zset"} {
+			blob, err := client.Dump(ctx, key).Result()
+			Expect(err).NotTo(HaveOccurred())
+
+			dst := key + "_copy"
+			Expect(client.Restore(ctx, dst, 0, blob).Err()).NotTo(HaveOccurred())
+			Expect(client.Type(ctx, dst).Val()).To(Equal(client.Type(ctx, key).Val()))
+		}
+
+		Expect(client.LRange(ctx, "dump_list_copy", 0, -1).Val()).To(Equal([]string{"a", "b", "c"}))
+		Expect(client.HGetAll(ctx, "dump_hash_copy").Val()).To(Equal(map[string]string{"f1": "v1", "f2": "v2"}))
+		Expect(client.SMembers(ctx, "dump_set_copy").Val()).To(ConsistOf("x", "y", "z"))
+		Expect(client.ZScore(ctx, "dump_zset_copy", "b").Val()).To(Equal(float64(2)))
+	})
+
+	It("should apply the relative ttl given to RESTORE", func() {
+		Expect(client.Set(ctx, "dump_ttl", "v", 0).Err()).NotTo(HaveOccurred())
+		blob, err := client.Dump(ctx, "dump_ttl").Result()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(client.Restore(ctx, "dump_ttl_copy", 5*time.Second, blob).Err()).NotTo(HaveOccurred())
+		Expect(client.TTL(ctx, "dump_ttl_copy").Val()).To(BeNumerically("~", 5*time.Second, time.Second))
+	})
+
+	It("should refuse to overwrite an existing key without REPLACE", func() {
+		Expect(client.Set(ctx, "dump_replace", "v1", 0).Err()).NotTo(HaveOccurred())
+		blob, err := client.Dump(ctx, "dump_replace").Result()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(client.Set(ctx, "dump_replace_dst", "v2", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Restore(ctx, "dump_replace_dst", 0, blob).Err()).To(HaveOccurred())
+
+		Expect(client.RestoreReplace(ctx, "dump_replace_dst", 0, blob).Err()).NotTo(HaveOccurred())
+		Expect(client.Get(ctx, "dump_replace_dst").Val()).To(Equal("v1"))
+	})
+
+	It("should reject a RESTORE payload with a bad CRC", func() {
+		Expect(client.Set(ctx, "dump_crc", "v", 0).Err()).NotTo(HaveOccurred())
+		blob, err := client.Dump(ctx, "dump_crc").Result()
+		Expect(err).NotTo(HaveOccurred())
+
+		corrupted := []byte(blob)
+		corrupted[len(corrupted)-1] ^= 0xFF
+
+		err = client.Restore(ctx, "dump_crc_copy", 0, string(corrupted)).Err()
+		Expect(err).To(MatchError(ContainSubstring("DUMP payload version or checksum are wrong")))
+	})
+})
@@ -161,6 +161,73 @@ var _ = Describe("Keyspace", Ordered, func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(n).To(Equal(int64(0)))
 		}
+		{
+			// set exat
+			res, err := client.Do(ctx, "set", "a", "a", "EXAT", time.Now().Add(time.Second*60).Unix()).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal("OK"))
+
+			n, err := client.Exists(ctx, "a").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(int64(1)))
+			Expect(client.TTL(ctx, "a").Val()).To(BeNumerically("~", 60*time.Second, 2*time.Second))
+		}
+		{
+			// set pxat
+			res, err := client.Do(ctx, "set", "a", "a", "PXAT", time.Now().Add(time.Second*60).UnixMilli()).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal("OK"))
+			Expect(client.TTL(ctx, "a").Val()).To(BeNumerically("~", 60*time.Second, 2*time.Second))
+		}
+		{
+			// set keepttl preserves the existing PEXPIREAT
+			res, err := client.Set(ctx, "a", "a", 0).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal("OK"))
+			Expect(client.PExpireAt(ctx, "a", time.Now().Add(time.Second*60)).Val()).To(Equal(true))
+
+			res, err = client.Do(ctx, "set", "a", "b", "KEEPTTL").Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal("OK"))
+			Expect(client.TTL(ctx, "a").Val()).NotTo(Equal(time.Duration(-1)))
+
+			// set without KEEPTTL clears the TTL
+			res, err = client.Set(ctx, "a", "c", 0).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal("OK"))
+			Expect(client.TTL(ctx, "a").Val()).To(Equal(time.Duration(-1)))
+
+			Expect(client.Del(ctx, "a").Err()).NotTo(HaveOccurred())
+		}
+		{
+			// set get returns the previous value
+			Expect(client.Set(ctx, "a", "old", 0).Err()).NotTo(HaveOccurred())
+
+			res, err := client.SetArgs(ctx, "a", "new", redis.SetArgs{Get: true}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal("old"))
+			Expect(client.Get(ctx, "a").Val()).To(Equal("new"))
+
+			// set get on a missing key returns nil and still sets the value
+			Expect(client.Del(ctx, "a").Err()).NotTo(HaveOccurred())
+			_, err = client.SetArgs(ctx, "a", "v1", redis.SetArgs{Get: true}).Result()
+			Expect(err).To(MatchError(redis.Nil))
+			Expect(client.Get(ctx, "a").Val()).To(Equal("v1"))
+
+			// set get + nx on an existing key returns the old value and does not set
+			res, err = client.SetArgs(ctx, "a", "v2", redis.SetArgs{Get: true, Mode: "NX"}).Result()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal("v1"))
+			Expect(client.Get(ctx, "a").Val()).To(Equal("v1"))
+
+			Expect(client.Del(ctx, "a").Err()).NotTo(HaveOccurred())
+		}
+		{
+			// mutually exclusive TTL options are a syntax error
+			Expect(client.Do(ctx, "set", "a", "a", "EX", "10", "PX", "10000").Err()).To(HaveOccurred())
+			Expect(client.Do(ctx, "set", "a", "a", "EX", "10", "KEEPTTL").Err()).To(HaveOccurred())
+			Expect(client.Do(ctx, "set", "a", "a", "NX", "XX").Err()).To(HaveOccurred())
+		}
 	})
 
 	//TODO(dingxiaoshuai) Add more test cases.
@@ -241,6 +308,59 @@ var _ = Describe("Keyspace", Ordered, func() {
 
 	})
 
+	It("should expire with NX/XX/GT/LT flags", func() {
+		Expect(client.Set(ctx, "key_flags", "value", 0).Val()).To(Equal(OK))
+
+		// NX: no TTL yet, so it succeeds and installs one.
+		Expect(client.Do(ctx, "expire", "key_flags", "100", "NX").Int()).To(Equal(1))
+		// NX again: a TTL is now present, so it is rejected.
+		Expect(client.Do(ctx, "expire", "key_flags", "100", "NX").Int()).To(Equal(0))
+
+		// XX: a TTL is present, so it succeeds.
+		Expect(client.Do(ctx, "expire", "key_flags", "200", "XX").Int()).To(Equal(1))
+		Expect(client.TTL(ctx, "key_flags").Val()).To(Equal(200 * time.Second))
+
+		// GT: 50 is not greater than the current 200, so it is rejected.
+		Expect(client.Do(ctx, "expire", "key_flags", "50", "GT").Int()).To(Equal(0))
+		// GT: 300 is greater than the current 200, so it succeeds.
+		Expect(client.Do(ctx, "expire", "key_flags", "300", "GT").Int()).To(Equal(1))
+
+		// LT: 400 is not less than the current 300, so it is rejected.
+		Expect(client.Do(ctx, "expire", "key_flags", "400", "LT").Int()).To(Equal(0))
+		// LT: 100 is less than the current 300, so it succeeds.
+		Expect(client.Do(ctx, "expire", "key_flags", "100", "LT").Int()).To(Equal(1))
+
+		// A key with no TTL is treated as +Inf: GT always fails, LT always succeeds.
+		Expect(client.Persist(ctx, "key_flags").Val()).To(Equal(true))
+		Expect(client.Do(ctx, "expire", "key_flags", "100", "GT").Int()).To(Equal(0))
+		Expect(client.Do(ctx, "expire", "key_flags", "100", "LT").Int()).To(Equal(1))
+
+		// Mutually exclusive combinations are a syntax error.
+		Expect(client.Do(ctx, "expire", "key_flags", "100", "NX", "XX").Err()).To(HaveOccurred())
+		Expect(client.Do(ctx, "expire", "key_flags", "100", "NX", "GT").Err()).To(HaveOccurred())
+		Expect(client.Do(ctx, "expire", "key_flags", "100", "NX", "LT").Err()).To(HaveOccurred())
+		Expect(client.Do(ctx, "expire", "key_flags", "100", "GT", "LT").Err()).To(HaveOccurred())
+
+		Expect(client.Del(ctx, "key_flags").Err()).NotTo(HaveOccurred())
+	})
+
+	It("should support EXPIRETIME and PEXPIRETIME", func() {
+		Expect(client.Exists(ctx, "key_expiretime").Val()).To(Equal(int64(0)))
+		Expect(client.Do(ctx, "expiretime", "key_expiretime").Int()).To(Equal(-2))
+
+		Expect(client.Set(ctx, "key_expiretime", "value", 0).Val()).To(Equal(OK))
+		Expect(client.Do(ctx, "expiretime", "key_expiretime").Int()).To(Equal(-1))
+		Expect(client.Do(ctx, "pexpiretime", "key_expiretime").Int()).To(Equal(-1))
+
+		deadline := time.Now().Add(100 * time.Second)
+		Expect(client.ExpireAt(ctx, "key_expiretime", deadline).Val()).To(Equal(true))
+
+		Expect(client.Do(ctx, "expiretime", "key_expiretime").Int()).To(Equal(int(deadline.Unix())))
+		Expect(client.Do(ctx, "pexpiretime", "key_expiretime").Int()).To(Equal(int(deadline.Unix()) * 1000))
+
+		Expect(client.Del(ctx, "key_expiretime").Err()).NotTo(HaveOccurred())
+	})
+
 	It("TTL", func() {
 		set := client.Set(ctx, "key1", "bcd", 10*time.Minute)
 		Expect(set.Err()).NotTo(HaveOccurred())
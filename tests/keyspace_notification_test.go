@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2023-present, Qihoo, Inc.  All rights reserved.
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree. An additional grant
+ * of patent rights can be found in the PATENTS file in the same directory.
+ */
+
+package pikiwidb_test
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/OpenAtomFoundation/pikiwidb/tests/util"
+)
+
+var _ = Describe("Keyspace notifications", Ordered, func() {
+	var (
+		ctx    = context.TODO()
+		s      *util.Server
+		client *redis.Client
+	)
+
+	BeforeAll(func() {
+		config := util.GetConfPath(false, 0)
+
+		s = util.StartServer(config, map[string]string{
+			"port":                   strconv.Itoa(7779),
+			"notify-keyspace-events": "KEA",
+		}, true)
+		Expect(s).NotTo(Equal(nil))
+	})
+
+	AfterAll(func() {
+		err := s.Close()
+		if err != nil {
+			log.Println("Close Server fail.", err.Error())
+			return
+		}
+	})
+
+	BeforeEach(func() {
+		client = s.NewClient()
+		Expect(client.FlushDB(ctx).Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		err := client.Close()
+		if err != nil {
+			log.Println("Close client conn fail.", err.Error())
+			return
+		}
+	})
+
+	waitForMessage := func(sub *redis.PubSub) *redis.Message {
+		select {
+		case msg := <-sub.Channel():
+			return msg
+		case <-time.After(3 * time.Second):
+			Fail("timed out waiting for keyspace notification")
+			return nil
+		}
+	}
+
+	It("should publish a keyspace and keyevent notification on SET", func() {
+		keyspaceSub := client.Subscribe(ctx, "__keyspace@0__:notify_key")
+		defer keyspaceSub.Close()
+		keyeventSub := client.Subscribe(ctx, "__keyevent@0__:set")
+		defer keyeventSub.Close()
+		Expect(keyspaceSub.Receive(ctx)).Error().NotTo(HaveOccurred())
+		Expect(keyeventSub.Receive(ctx)).Error().NotTo(HaveOccurred())
+
+		other := s.NewClient()
+		defer other.Close()
+		Expect(other.Set(ctx, "notify_key", "v", 0).Err()).NotTo(HaveOccurred())
+
+		msg := waitForMessage(keyspaceSub)
+		Expect(msg.Payload).To(Equal("set"))
+
+		msg = waitForMessage(keyeventSub)
+		Expect(msg.Payload).To(Equal("notify_key"))
+	})
+
+	It("should publish a notification on DEL", func() {
+		other := s.NewClient()
+		defer other.Close()
+		Expect(other.Set(ctx, "notify_del", "v", 0).Err()).NotTo(HaveOccurred())
+
+		sub := client.Subscribe(ctx, "__keyevent@0__:del")
+		defer sub.Close()
+		Expect(sub.Receive(ctx)).Error().NotTo(HaveOccurred())
+
+		Expect(other.Del(ctx, "notify_del").Err()).NotTo(HaveOccurred())
+
+		msg := waitForMessage(sub)
+		Expect(msg.Payload).To(Equal("notify_del"))
+	})
+
+	It("should publish a notification on EXPIRE and RENAME", func() {
+		other := s.NewClient()
+		defer other.Close()
+		Expect(other.Set(ctx, "notify_expire", "v", 0).Err()).NotTo(HaveOccurred())
+		Expect(other.Set(ctx, "notify_rename", "v", 0).Err()).NotTo(HaveOccurred())
+
+		expireSub := client.Subscribe(ctx, "__keyevent@0__:expire")
+		defer expireSub.Close()
+		renameSub := client.Subscribe(ctx, "__keyevent@0__:rename_from")
+		defer renameSub.Close()
+		Expect(expireSub.Receive(ctx)).Error().NotTo(HaveOccurred())
+		Expect(renameSub.Receive(ctx)).Error().NotTo(HaveOccurred())
+
+		Expect(other.Expire(ctx, "notify_expire", 100*time.Second).Err()).NotTo(HaveOccurred())
+		Expect(waitForMessage(expireSub).Payload).To(Equal("notify_expire"))
+
+		Expect(other.Rename(ctx, "notify_rename", "notify_renamed").Err()).NotTo(HaveOccurred())
+		Expect(waitForMessage(renameSub).Payload).To(Equal("notify_rename"))
+	})
+
+	It("should publish an expired notification only after the key is actually gone", func() {
+		other := s.NewClient()
+		defer other.Close()
+		Expect(other.Set(ctx, "notify_expired", "v", time.Second).Err()).NotTo(HaveOccurred())
+
+		sub := client.Subscribe(ctx, "__keyevent@0__:expired")
+		defer sub.Close()
+		Expect(sub.Receive(ctx)).Error().NotTo(HaveOccurred())
+
+		msg := waitForMessage(sub)
+		Expect(msg.Payload).To(Equal("notify_expired"))
+		Expect(other.Exists(ctx, "notify_expired").Val()).To(Equal(int64(0)))
+	})
+})
@@ -0,0 +1,230 @@
+/*
+ * Copyright (c) 2023-present, Qihoo, Inc.  All rights reserved.
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree. An additional grant
+ * of patent rights can be found in the PATENTS file in the same directory.
+ */
+
+package pikiwidb_test
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/OpenAtomFoundation/pikiwidb/tests/util"
+)
+
+var _ = Describe("Keyspace", Ordered, func() {
+	var (
+		ctx    = context.TODO()
+		s      *util.Server
+		client *redis.Client
+	)
+
+	BeforeAll(func() {
+		config := util.GetConfPath(false, 0)
+
+		s = util.StartServer(config, map[string]string{"port": strconv.Itoa(7778)}, true)
+		Expect(s).NotTo(Equal(nil))
+	})
+
+	AfterAll(func() {
+		err := s.Close()
+		if err != nil {
+			log.Println("Close Server fail.", err.Error())
+			return
+		}
+	})
+
+	BeforeEach(func() {
+		client = s.NewClient()
+		Expect(client.FlushDB(ctx).Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		err := client.Close()
+		if err != nil {
+			log.Println("Close client conn fail.", err.Error())
+			return
+		}
+	})
+
+	// scanAll drives a SCAN-family cursor to completion and returns every key seen.
+	scanAll := func(scan func(cursor uint64) (keys []string, next uint64, err error)) []string {
+		var (
+			cursor uint64
+			all    []string
+		)
+		for {
+			keys, next, err := scan(cursor)
+			Expect(err).NotTo(HaveOccurred())
+			all = append(all, keys...)
+			cursor = next
+			if cursor == 0 {
+				break
+			}
+		}
+		return all
+	}
+
+	It("should scan the whole keyspace exactly once per key", func() {
+		for i := 0; i < 200; i++ {
+			Expect(client.Set(ctx, "scan:"+strconv.Itoa(i), "v", 0).Err()).NotTo(HaveOccurred())
+		}
+
+		keys := scanAll(func(cursor uint64) ([]string, uint64, error) {
+			res, next, err := client.Scan(ctx, cursor, "", 37).Result()
+			return res, next, err
+		})
+
+		Expect(keys).To(HaveLen(200))
+		seen := map[string]struct{}{}
+		for _, k := range keys {
+			_, dup := seen[k]
+			Expect(dup).To(BeFalse())
+			seen[k] = struct{}{}
+		}
+	})
+
+	It("should filter SCAN results with MATCH after the batch is read", func() {
+		Expect(client.Set(ctx, "scan:a1", "v", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Set(ctx, "scan:a2", "v", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Set(ctx, "other:b1", "v", 0).Err()).NotTo(HaveOccurred())
+
+		keys := scanAll(func(cursor uint64) ([]string, uint64, error) {
+			return client.Scan(ctx, cursor, "scan:*", 10).Result()
+		})
+
+		Expect(keys).To(ConsistOf("scan:a1", "scan:a2"))
+	})
+
+	It("should restrict SCAN results with TYPE", func() {
+		Expect(client.Set(ctx, "scan:str", "v", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.LPush(ctx, "scan:list", "v").Err()).NotTo(HaveOccurred())
+		Expect(client.SAdd(ctx, "scan:set", "v").Err()).NotTo(HaveOccurred())
+
+		keys := scanAll(func(cursor uint64) ([]string, uint64, error) {
+			return client.ScanType(ctx, cursor, "scan:*", 10, "string").Result()
+		})
+
+		Expect(keys).To(ConsistOf("scan:str"))
+	})
+
+	It("should HSCAN a hash's fields and values", func() {
+		Expect(client.HSet(ctx, "myhash", "f1", "v1", "f2", "v2", "f3", "v3").Err()).NotTo(HaveOccurred())
+
+		var all []string
+		var cursor uint64
+		for {
+			res, next, err := client.HScan(ctx, "myhash", cursor, "", 2).Result()
+			Expect(err).NotTo(HaveOccurred())
+			all = append(all, res...)
+			cursor = next
+			if cursor == 0 {
+				break
+			}
+		}
+
+		Expect(all).To(ConsistOf("f1", "v1", "f2", "v2", "f3", "v3"))
+	})
+
+	It("should SSCAN a set's members", func() {
+		Expect(client.SAdd(ctx, "myset", "m1", "m2", "m3").Err()).NotTo(HaveOccurred())
+
+		members := scanAll(func(cursor uint64) ([]string, uint64, error) {
+			return client.SScan(ctx, "myset", cursor, "", 2).Result()
+		})
+
+		Expect(members).To(ConsistOf("m1", "m2", "m3"))
+	})
+
+	It("should ZSCAN a zset's members and scores", func() {
+		Expect(client.ZAdd(ctx, "myzset",
+			redis.Z{Score: 1, Member: "one"},
+			redis.Z{Score: 2, Member: "two"},
+			redis.Z{Score: 3, Member: "three"},
+		).Err()).NotTo(HaveOccurred())
+
+		all := scanAll(func(cursor uint64) ([]string, uint64, error) {
+			return client.ZScan(ctx, "myzset", cursor, "", 2).Result()
+		})
+
+		Expect(all).To(ConsistOf("one", "1", "two", "2", "three", "3"))
+	})
+
+	It("should allow a zero-result SCAN batch with a non-zero cursor during a full pass", func() {
+		for i := 0; i < 50; i++ {
+			Expect(client.Set(ctx, "nomatch:"+strconv.Itoa(i), "v", 0).Err()).NotTo(HaveOccurred())
+		}
+
+		keys := scanAll(func(cursor uint64) ([]string, uint64, error) {
+			return client.Scan(ctx, cursor, "never-matches-*", 10).Result()
+		})
+
+		Expect(keys).To(BeEmpty())
+	})
+
+	It("should COPY a string and preserve its TTL", func() {
+		Expect(client.Set(ctx, "copy:src", "hello", 100*time.Second).Err()).NotTo(HaveOccurred())
+
+		Expect(client.Copy(ctx, "copy:src", "copy:dst", 0, false).Val()).To(Equal(int64(1)))
+		Expect(client.Get(ctx, "copy:dst").Val()).To(Equal("hello"))
+		Expect(client.TTL(ctx, "copy:dst").Val()).To(BeNumerically("~", 100*time.Second, 5*time.Second))
+
+		// source is untouched
+		Expect(client.Get(ctx, "copy:src").Val()).To(Equal("hello"))
+	})
+
+	It("should COPY each data type with a deep copy of the value", func() {
+		Expect(client.LPush(ctx, "copy:list", "a", "b", "c").Err()).NotTo(HaveOccurred())
+		Expect(client.Copy(ctx, "copy:list", "copy:list2", 0, false).Val()).To(Equal(int64(1)))
+		Expect(client.LRange(ctx, "copy:list2", 0, -1).Val()).To(Equal(client.LRange(ctx, "copy:list", 0, -1).Val()))
+		Expect(client.RPush(ctx, "copy:list", "d").Err()).NotTo(HaveOccurred())
+		Expect(client.LRange(ctx, "copy:list2", 0, -1).Val()).NotTo(Equal(client.LRange(ctx, "copy:list", 0, -1).Val()))
+
+		Expect(client.HSet(ctx, "copy:hash", "f", "v").Err()).NotTo(HaveOccurred())
+		Expect(client.Copy(ctx, "copy:hash", "copy:hash2", 0, false).Val()).To(Equal(int64(1)))
+		Expect(client.HGet(ctx, "copy:hash2", "f").Val()).To(Equal("v"))
+
+		Expect(client.SAdd(ctx, "copy:set", "m").Err()).NotTo(HaveOccurred())
+		Expect(client.Copy(ctx, "copy:set", "copy:set2", 0, false).Val()).To(Equal(int64(1)))
+		Expect(client.SIsMember(ctx, "copy:set2", "m").Val()).To(BeTrue())
+
+		Expect(client.ZAdd(ctx, "copy:zset", redis.Z{Score: 1, Member: "m"}).Err()).NotTo(HaveOccurred())
+		Expect(client.Copy(ctx, "copy:zset", "copy:zset2", 0, false).Val()).To(Equal(int64(1)))
+		Expect(client.ZScore(ctx, "copy:zset2", "m").Val()).To(Equal(float64(1)))
+	})
+
+	It("should refuse to overwrite an existing destination without REPLACE", func() {
+		Expect(client.Set(ctx, "copy:src2", "a", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Set(ctx, "copy:dst2", "b", 0).Err()).NotTo(HaveOccurred())
+
+		Expect(client.Copy(ctx, "copy:src2", "copy:dst2", 0, false).Val()).To(Equal(int64(0)))
+		Expect(client.Get(ctx, "copy:dst2").Val()).To(Equal("b"))
+
+		Expect(client.Copy(ctx, "copy:src2", "copy:dst2", 0, true).Val()).To(Equal(int64(1)))
+		Expect(client.Get(ctx, "copy:dst2").Val()).To(Equal("a"))
+	})
+
+	It("should COPY across logical databases", func() {
+		Expect(client.Set(ctx, "copy:crossdb", "v", 0).Err()).NotTo(HaveOccurred())
+
+		Expect(client.Do(ctx, "copy", "copy:crossdb", "copy:crossdb", "DB", 1).Int()).To(Equal(1))
+
+		other := s.NewClient()
+		defer other.Close()
+		Expect(other.Do(ctx, "select", 1).Err()).NotTo(HaveOccurred())
+		Expect(other.Get(ctx, "copy:crossdb").Val()).To(Equal("v"))
+	})
+
+	It("should reject copying a key onto itself", func() {
+		Expect(client.Set(ctx, "copy:self", "v", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Copy(ctx, "copy:self", "copy:self", 0, false).Err()).To(HaveOccurred())
+	})
+})
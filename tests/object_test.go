@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2023-present, Qihoo, Inc.  All rights reserved.
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree. An additional grant
+ * of patent rights can be found in the PATENTS file in the same directory.
+ */
+
+package pikiwidb_test
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/OpenAtomFoundation/pikiwidb/tests/util"
+)
+
+var _ = Describe("Object", Ordered, func() {
+	var (
+		ctx    = context.TODO()
+		s      *util.Server
+		client *redis.Client
+	)
+
+	BeforeAll(func() {
+		config := util.GetConfPath(false, 0)
+
+		s = util.StartServer(config, map[string]string{"port": strconv.Itoa(7780)}, true)
+		Expect(s).NotTo(Equal(nil))
+	})
+
+	AfterAll(func() {
+		err := s.Close()
+		if err != nil {
+			log.Println("Close Server fail.", err.Error())
+			return
+		}
+	})
+
+	BeforeEach(func() {
+		client = s.NewClient()
+		Expect(client.FlushDB(ctx).Err()).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		err := client.Close()
+		if err != nil {
+			log.Println("Close client conn fail.", err.Error())
+			return
+		}
+	})
+
+	It("should report OBJECT ENCODING for strings", func() {
+		Expect(client.Set(ctx, "str_int", "12345", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.ObjectEncoding(ctx, "str_int").Val()).To(Equal("int"))
+
+		Expect(client.Set(ctx, "str_short", "hello", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.ObjectEncoding(ctx, "str_short").Val()).To(Equal("embstr"))
+
+		long := make([]byte, 64)
+		for i := range long {
+			long[i] = 'a'
+		}
+		Expect(client.Set(ctx, "str_long", string(long), 0).Err()).NotTo(HaveOccurred())
+		Expect(client.ObjectEncoding(ctx, "str_long").Val()).To(Equal("raw"))
+	})
+
+	It("should report OBJECT ENCODING for lists, hashes, sets and zsets", func() {
+		Expect(client.LPush(ctx, "small_list", "a", "b").Err()).NotTo(HaveOccurred())
+		Expect(client.ObjectEncoding(ctx, "small_list").Val()).To(Equal("listpack"))
+
+		Expect(client.HSet(ctx, "small_hash", "f", "v").Err()).NotTo(HaveOccurred())
+		Expect(client.ObjectEncoding(ctx, "small_hash").Val()).To(Equal("listpack"))
+
+		Expect(client.SAdd(ctx, "int_set", "1", "2", "3").Err()).NotTo(HaveOccurred())
+		Expect(client.ObjectEncoding(ctx, "int_set").Val()).To(Equal("intset"))
+
+		Expect(client.SAdd(ctx, "str_set", "a", "b").Err()).NotTo(HaveOccurred())
+		Expect(client.ObjectEncoding(ctx, "str_set").Val()).To(Equal("listpack"))
+
+		Expect(client.ZAdd(ctx, "small_zset", redis.Z{Score: 1, Member: "a"}).Err()).NotTo(HaveOccurred())
+		Expect(client.ObjectEncoding(ctx, "small_zset").Val()).To(Equal("listpack"))
+
+		for i := 0; i < 200; i++ {
+			Expect(client.RPush(ctx, "big_list", strconv.Itoa(i)).Err()).NotTo(HaveOccurred())
+		}
+		Expect(client.ObjectEncoding(ctx, "big_list").Val()).To(Equal("quicklist"))
+	})
+
+	It("should return OBJECT REFCOUNT of 1 for non-shared objects", func() {
+		Expect(client.Set(ctx, "refcount_key", "v", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.ObjectRefCount(ctx, "refcount_key").Val()).To(Equal(int64(1)))
+	})
+
+	It("should report OBJECT IDLETIME under an LRU policy", func() {
+		s2 := util.StartServer(util.GetConfPath(false, 0), map[string]string{
+			"port":             strconv.Itoa(7781),
+			"maxmemory-policy": "allkeys-lru",
+		}, true)
+		defer s2.Close()
+		c2 := s2.NewClient()
+		defer c2.Close()
+
+		Expect(c2.Set(ctx, "idle_key", "v", 0).Err()).NotTo(HaveOccurred())
+		time.Sleep(2 * time.Second)
+		Expect(c2.ObjectIdleTime(ctx, "idle_key").Val()).To(BeNumerically(">=", 1*time.Second))
+	})
+
+	It("should report OBJECT FREQ under an LFU policy and error otherwise", func() {
+		Expect(client.Set(ctx, "freq_key", "v", 0).Err()).NotTo(HaveOccurred())
+		Expect(client.Do(ctx, "object", "freq", "freq_key").Err()).To(HaveOccurred())
+
+		s2 := util.StartServer(util.GetConfPath(false, 0), map[string]string{
+			"port":             strconv.Itoa(7782),
+			"maxmemory-policy": "allkeys-lfu",
+		}, true)
+		defer s2.Close()
+		c2 := s2.NewClient()
+		defer c2.Close()
+
+		Expect(c2.Set(ctx, "freq_key", "v", 0).Err()).NotTo(HaveOccurred())
+		Expect(c2.Get(ctx, "freq_key").Err()).NotTo(HaveOccurred())
+		Expect(c2.Do(ctx, "object", "freq", "freq_key").Int()).To(BeNumerically(">=", 0))
+	})
+
+	It("should print OBJECT HELP", func() {
+		Expect(client.Do(ctx, "object", "help").Err()).NotTo(HaveOccurred())
+	})
+})